@@ -0,0 +1,51 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"math/big"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ContractSubscriber defines the methods needed to create, query, and watch
+// EIP-8082 event subscriptions against a contract, parallel to
+// ContractFilterer for one-off and streaming log queries.
+type ContractSubscriber interface {
+	// SubscribeEvent submits a transaction that registers a new on-chain
+	// subscription for the given target contract/event/topic-filter
+	// combination and returns the off-chain-computed subscription ID together
+	// with the transaction that creates it.
+	SubscribeEvent(opts *TransactOpts, target common.Address, eventSig common.Hash, callback common.Address, selector [4]byte, topics [3][]common.Hash, gasLimit uint64, gasPrice *big.Int) (common.Hash, *types.Transaction, error)
+
+	// SubscriptionByID returns the on-chain state of a subscription, or nil if
+	// it does not exist.
+	SubscriptionByID(ctx context.Context, id common.Hash) (*types.Subscription, error)
+
+	// SubscriptionsByTarget pages through subscriptions registered against
+	// target for eventSig, starting after from entries and returning at most
+	// count of them.
+	SubscriptionsByTarget(ctx context.Context, target common.Address, eventSig common.Hash, from, count uint64) ([]*types.Subscription, error)
+
+	// WatchCallbacks streams types.CallbackExecution records as blocks
+	// matching target/eventSig are imported, via the subscription_ RPC
+	// namespace's "callbacks" stream.
+	WatchCallbacks(ctx context.Context, target common.Address, eventSig common.Hash, ch chan<- *types.CallbackExecution) (ethereum.Subscription, error)
+}
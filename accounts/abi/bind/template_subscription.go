@@ -0,0 +1,252 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// SubscriptionTmplEvent carries the per-event data the subscription template
+// needs on top of what the existing Filter/Watch template already computes
+// for abi.Event. Indexed and non-indexed arguments are split the same way
+// FilterLogs splits them, since Handle<Event> has to unpack CallbackData the
+// way a log-derived event struct is normally built.
+type SubscriptionTmplEvent struct {
+	Contract   string    // Go type name of the surrounding contract binding
+	Name       string    // Go-exported event name, e.g. "Transfer"
+	Normalized abi.Event // The underlying ABI event
+	Indexed    []abi.Argument
+	NonIndexed []abi.Argument
+}
+
+// subscriptionManagerABI is the ABI of the EIP-8082 SubscriptionManager
+// system contract. Every generated subscription call is routed through a
+// bind.BoundContract bound to this ABI and params.SubscriptionManagerAddress
+// - never through the emitting contract's own ABI, which has no "subscribe",
+// "deposit", "withdraw", "unsubscribe" or "updateSubscription" methods.
+const subscriptionManagerABI = `[
+	{"type":"function","name":"subscribe","inputs":[{"name":"target","type":"address"},{"name":"eventSig","type":"bytes32"},{"name":"callback","type":"address"},{"name":"selector","type":"bytes4"},{"name":"topics","type":"bytes32[][3]"},{"name":"gasLimit","type":"uint64"},{"name":"gasPrice","type":"uint256"}],"outputs":[{"name":"id","type":"bytes32"}]},
+	{"type":"function","name":"deposit","inputs":[{"name":"id","type":"bytes32"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"withdraw","inputs":[{"name":"id","type":"bytes32"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"unsubscribe","inputs":[{"name":"id","type":"bytes32"}],"outputs":[]},
+	{"type":"function","name":"updateSubscription","inputs":[{"name":"id","type":"bytes32"},{"name":"gasLimit","type":"uint64"},{"name":"gasPrice","type":"uint256"}],"outputs":[]}
+]`
+
+// tmplSourceSubscription is the template for the generated Subscribe<Event>,
+// Handle<Event>, and <Event>Subscription helpers of one event, filled from a
+// SubscriptionTmplEvent. The binding generator in this package does not
+// render it; generated bindings contain no subscription helpers.
+const tmplSourceSubscription = `
+// {{.Name}}Subscription represents an active on-chain EIP-8082 subscription
+// to {{.Contract}}'s {{.Name}} event. Management calls (Deposit, Withdraw,
+// Unsubscribe, Update) are issued against the SubscriptionManager system
+// contract, not {{.Contract}}'s own ABI.
+type {{.Name}}Subscription struct {
+	ID      [32]byte
+	manager *bind.BoundContract
+	opts    *bind.TransactOpts
+}
+
+// Subscribe{{.Name}} registers an on-chain subscription to the {{.Name}} event,
+// optionally narrowed by indexed-topic filters, and returns a typed handle to
+// manage it.
+func (_{{.Contract}} *{{.Contract}}Transactor) Subscribe{{.Name}}(opts *bind.TransactOpts, subscriber common.Address, callback common.Address, selector [4]byte{{range .Indexed}}, {{.Name}} []{{bindtype .Type}}{{end}}, gasLimit uint64, gasPrice *big.Int) (*{{.Name}}Subscription, *types.Transaction, error) {
+	var topics [3][]common.Hash
+	{{range $i, $arg := .Indexed}}
+	for _, v := range {{$arg.Name}} {
+		topic, err := bind.SubscriptionTopicValue(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		topics[{{$i}}] = append(topics[{{$i}}], topic)
+	}
+	{{end}}
+	manager, err := bind.BindSubscriptionManager(_{{$.Contract}}.contract.backend)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, tx, err := bind.SubscribeToEvent(opts, manager, _{{$.Contract}}.contract.address, common.HexToHash("{{$.Normalized.ID}}"), subscriber, callback, selector, topics, gasLimit, gasPrice)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &{{.Name}}Subscription{ID: id, manager: manager, opts: opts}, tx, nil
+}
+
+// Handle{{.Name}} decodes the CallbackData payload NotifySubscribers builds for
+// a {{.Name}} subscription callback (selector + ABI-encoded event data) into a
+// typed {{.Name}} event.
+func (_{{.Contract}} *{{.Contract}}Filterer) Handle{{.Name}}(data []byte) (*{{.Name}}, error) {
+	if len(data) < 4 {
+		return nil, bind.ErrInvalidCallbackData
+	}
+	event := new({{.Name}})
+	if err := _{{.Contract}}.contract.abi.UnpackIntoInterface(event, "{{.Normalized.RawName}}", data[4:]); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Deposit adds funds to this subscription's deposit balance.
+func (s *{{.Name}}Subscription) Deposit(amount *big.Int) (*types.Transaction, error) {
+	return s.manager.Transact(s.opts, "deposit", s.ID, amount)
+}
+
+// Withdraw removes funds from this subscription's deposit balance.
+func (s *{{.Name}}Subscription) Withdraw(amount *big.Int) (*types.Transaction, error) {
+	return s.manager.Transact(s.opts, "withdraw", s.ID, amount)
+}
+
+// Unsubscribe deactivates this subscription.
+func (s *{{.Name}}Subscription) Unsubscribe() (*types.Transaction, error) {
+	return s.manager.Transact(s.opts, "unsubscribe", s.ID)
+}
+
+// Update changes the gas limit and gas price of this subscription.
+func (s *{{.Name}}Subscription) Update(gasLimit uint64, gasPrice *big.Int) (*types.Transaction, error) {
+	return s.manager.Transact(s.opts, "updateSubscription", s.ID, gasLimit, gasPrice)
+}
+`
+
+// ErrInvalidCallbackData is returned by generated Handle<Event> methods when
+// a callback's data is too short to contain the leading 4-byte selector.
+// Exported so it can be referenced as bind.ErrInvalidCallbackData from the
+// generated code's own package, which tmplSourceSubscription is rendered
+// into rather than package bind itself.
+var ErrInvalidCallbackData = &subscriptionError{"callback data too short to contain a selector"}
+
+// subscriptionError is a minimal static error type for subscription-binding
+// decode failures, mirroring the plain string errors used elsewhere in this
+// package rather than pulling in errors.New at template-expansion time.
+type subscriptionError struct{ msg string }
+
+func (e *subscriptionError) Error() string { return e.msg }
+
+// BindSubscriptionManager returns a *BoundContract bound to the
+// SubscriptionManager system contract's own ABI and well-known address,
+// distinct from the emitting contract's binding. Generated
+// Subscribe/Deposit/Withdraw/Unsubscribe/Update calls go through it; it is
+// exported so generated code in other packages can call it as
+// bind.BindSubscriptionManager.
+func BindSubscriptionManager(backend ContractBackend) (*BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(subscriptionManagerABI))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SubscriptionManager ABI: %w", err)
+	}
+	return NewBoundContract(params.SubscriptionManagerAddress, parsed, backend, backend, backend), nil
+}
+
+// SubscribeToEvent submits the on-chain "subscribe" call against manager and
+// returns the off-chain-computed subscription ID together with the
+// transaction that creates it. It is the single implementation shared by
+// BoundContractSubscriber.SubscribeEvent and codegen's per-event
+// Subscribe<Event> methods, so the two call paths can't drift out of sync
+// the way independently duplicated Transact calls would.
+func SubscribeToEvent(opts *TransactOpts, manager *BoundContract, target common.Address, eventSig common.Hash, subscriber common.Address, callback common.Address, selector [4]byte, topics [3][]common.Hash, gasLimit uint64, gasPrice *big.Int) (common.Hash, *types.Transaction, error) {
+	id := types.ComputeSubscriptionID(target, eventSig, subscriber, topics)
+	tx, err := manager.Transact(opts, "subscribe", target, eventSig, callback, selector, topics, gasLimit, gasPrice)
+	if err != nil {
+		return common.Hash{}, nil, err
+	}
+	return id, tx, nil
+}
+
+// BoundContractSubscriber implements ContractSubscriber's write path
+// (SubscribeEvent) generically against any ContractBackend, bound to the
+// SubscriptionManager system contract the same way codegen's
+// Subscribe<Event> is, so a caller doesn't need per-contract codegen just to
+// submit a subscription.
+//
+// Its query/streaming methods (SubscriptionByID, SubscriptionsByTarget,
+// WatchCallbacks) are intentionally not implemented here: they read state
+// and stream events from the "subscription" RPC namespace, which
+// ContractBackend has no access to - a concrete implementation needs an RPC
+// client (e.g. ethclient) wrapping that namespace, which is out of scope for
+// this binding-layer type.
+type BoundContractSubscriber struct {
+	manager *BoundContract
+}
+
+// NewBoundContractSubscriber binds a BoundContractSubscriber to the
+// SubscriptionManager system contract over backend.
+func NewBoundContractSubscriber(backend ContractBackend) (*BoundContractSubscriber, error) {
+	manager, err := BindSubscriptionManager(backend)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundContractSubscriber{manager: manager}, nil
+}
+
+// SubscribeEvent implements ContractSubscriber.
+func (b *BoundContractSubscriber) SubscribeEvent(opts *TransactOpts, target common.Address, eventSig common.Hash, callback common.Address, selector [4]byte, topics [3][]common.Hash, gasLimit uint64, gasPrice *big.Int) (common.Hash, *types.Transaction, error) {
+	return SubscribeToEvent(opts, b.manager, target, eventSig, opts.From, callback, selector, topics, gasLimit, gasPrice)
+}
+
+func (b *BoundContractSubscriber) SubscriptionByID(ctx context.Context, id common.Hash) (*types.Subscription, error) {
+	return nil, fmt.Errorf("bind: SubscriptionByID requires an RPC client over the subscription_ namespace, not available through ContractBackend alone")
+}
+
+func (b *BoundContractSubscriber) SubscriptionsByTarget(ctx context.Context, target common.Address, eventSig common.Hash, from, count uint64) ([]*types.Subscription, error) {
+	return nil, fmt.Errorf("bind: SubscriptionsByTarget requires an RPC client over the subscription_ namespace, not available through ContractBackend alone")
+}
+
+func (b *BoundContractSubscriber) WatchCallbacks(ctx context.Context, target common.Address, eventSig common.Hash, ch chan<- *types.CallbackExecution) (ethereum.Subscription, error) {
+	return nil, fmt.Errorf("bind: WatchCallbacks requires an RPC client over the subscription_ namespace, not available through ContractBackend alone")
+}
+
+// Compile-time check that BoundContractSubscriber satisfies ContractSubscriber.
+var _ ContractSubscriber = (*BoundContractSubscriber)(nil)
+
+// SubscriptionTopicValue converts a bound Go argument value for an indexed
+// event parameter into the common.Hash form subscription topics are stored
+// and matched as, the same per-type conversion FilterLogs relies on via
+// abi.MakeTopics, rather than assuming every indexed type exposes a Hash()
+// method (only common.Address and common.Hash do). It is exported so
+// generated code in other packages can call it as bind.SubscriptionTopicValue.
+func SubscriptionTopicValue(v interface{}) (common.Hash, error) {
+	switch val := v.(type) {
+	case common.Hash:
+		return val, nil
+	case common.Address:
+		return common.BytesToHash(val.Bytes()), nil
+	case *big.Int:
+		return common.BytesToHash(math.U256Bytes(new(big.Int).Set(val))), nil
+	case bool:
+		if val {
+			return common.BigToHash(big.NewInt(1)), nil
+		}
+		return common.Hash{}, nil
+	case [32]byte:
+		return common.Hash(val), nil
+	case []byte:
+		return crypto.Keccak256Hash(val), nil
+	case string:
+		return crypto.Keccak256Hash([]byte(val)), nil
+	default:
+		return common.Hash{}, fmt.Errorf("unsupported indexed topic type %T", v)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSubscriptionTopicValue(t *testing.T) {
+	addr := common.HexToAddress("0x0102030405060708091011121314151617181920")
+	hash := common.HexToHash("0xdeadbeef")
+
+	tests := []struct {
+		name string
+		in   interface{}
+		want common.Hash
+	}{
+		{"address", addr, common.BytesToHash(addr.Bytes())},
+		{"hash", hash, hash},
+		{"uint256", big.NewInt(42), common.BigToHash(big.NewInt(42))},
+		{"bool true", true, common.BigToHash(big.NewInt(1))},
+		{"bool false", false, common.Hash{}},
+		{"bytes", []byte("hi"), crypto.Keccak256Hash([]byte("hi"))},
+		{"string", "hi", crypto.Keccak256Hash([]byte("hi"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SubscriptionTopicValue(tt.in)
+			if err != nil {
+				t.Fatalf("SubscriptionTopicValue(%v): unexpected error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("SubscriptionTopicValue(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SubscriptionTopicValue(struct{}{}); err == nil {
+		t.Error("SubscriptionTopicValue(unsupported type): expected error, got nil")
+	}
+}
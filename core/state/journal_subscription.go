@@ -0,0 +1,131 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// subscriptionChange records the creation or overwrite of a subscription's
+// static fields (everything but deposit balance and active flag, which get
+// their own finer-grained entries so that a deposit/withdraw in the same
+// block doesn't have to revert an entire subscription snapshot).
+type subscriptionChange struct {
+	id   common.Hash
+	prev *types.Subscription // nil if the subscription didn't previously exist
+}
+
+func (ch subscriptionChange) revert(s *StateDB) {
+	if ch.prev == nil {
+		delete(s.subscriptions, ch.id)
+		return
+	}
+	s.subscriptions[ch.id] = ch.prev
+}
+
+func (ch subscriptionChange) dirtied() *common.Address {
+	return nil
+}
+
+// subscriptionDepositChange records a change to a subscription's deposit
+// balance, mirroring balanceChange.
+type subscriptionDepositChange struct {
+	id   common.Hash
+	prev *big.Int
+}
+
+func (ch subscriptionDepositChange) revert(s *StateDB) {
+	if sub, ok := s.subscriptions[ch.id]; ok {
+		sub.DepositBalance = ch.prev
+	}
+}
+
+func (ch subscriptionDepositChange) dirtied() *common.Address {
+	return nil
+}
+
+// subscriptionActiveChange records a change to a subscription's Active flag,
+// e.g. unsubscribe or expiry-driven deactivation.
+type subscriptionActiveChange struct {
+	id   common.Hash
+	prev bool
+}
+
+func (ch subscriptionActiveChange) revert(s *StateDB) {
+	if sub, ok := s.subscriptions[ch.id]; ok {
+		sub.Active = ch.prev
+	}
+}
+
+func (ch subscriptionActiveChange) dirtied() *common.Address {
+	return nil
+}
+
+// subscriptionCallbackCountChange records a change to a subscription's
+// CallbackCount, bumped once per delivered callback and checked against
+// MaxCallbacks to decide expiry.
+type subscriptionCallbackCountChange struct {
+	id   common.Hash
+	prev uint64
+}
+
+func (ch subscriptionCallbackCountChange) revert(s *StateDB) {
+	if sub, ok := s.subscriptions[ch.id]; ok {
+		sub.CallbackCount = ch.prev
+	}
+}
+
+func (ch subscriptionCallbackCountChange) dirtied() *common.Address {
+	return nil
+}
+
+// subscriptionExpiryChange records a change to a subscription's
+// ExpiresAtBlock, e.g. a Renew call.
+type subscriptionExpiryChange struct {
+	id   common.Hash
+	prev uint64
+}
+
+func (ch subscriptionExpiryChange) revert(s *StateDB) {
+	if sub, ok := s.subscriptions[ch.id]; ok {
+		sub.ExpiresAtBlock = ch.prev
+	}
+}
+
+func (ch subscriptionExpiryChange) dirtied() *common.Address {
+	return nil
+}
+
+// subscriptionIndexChange records a removal from the subscribersByEvent
+// index for a (target, eventSig) key, e.g. RemoveSubscription's sweep. It
+// snapshots the whole prior slice, mirroring subscriptionChange, so a revert
+// restores the id at its original position rather than leaving it missing.
+type subscriptionIndexChange struct {
+	key  common.Hash
+	prev []common.Hash
+}
+
+func (ch subscriptionIndexChange) revert(s *StateDB) {
+	s.subscribersByEvent[ch.key] = ch.prev
+}
+
+func (ch subscriptionIndexChange) dirtied() *common.Address {
+	return nil
+}
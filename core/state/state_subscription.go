@@ -0,0 +1,182 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// GetSubscription returns the subscription for id, or nil if it doesn't exist.
+// The returned pointer is the live, journal-tracked copy; callers must not
+// mutate it directly - use SetSubscription, DepositSubscription,
+// WithdrawSubscription or SetSubscriptionActive so the change is journaled.
+func (s *StateDB) GetSubscription(id common.Hash) *types.Subscription {
+	return s.subscriptions[id]
+}
+
+// SetSubscription stores sub under id, journaling the previous value (nil if
+// none existed) so a revert restores exactly the prior state, including
+// across a snapshot taken before the subscription was first created.
+func (s *StateDB) SetSubscription(id common.Hash, sub *types.Subscription) {
+	s.journal.append(subscriptionChange{
+		id:   id,
+		prev: s.subscriptions[id],
+	})
+	s.subscriptions[id] = sub
+}
+
+// DepositSubscription journals and applies a deposit balance change.
+func (s *StateDB) DepositSubscription(id common.Hash, amount *big.Int) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return
+	}
+	s.journal.append(subscriptionDepositChange{
+		id:   id,
+		prev: new(big.Int).Set(sub.DepositBalance),
+	})
+	sub.DepositBalance.Add(sub.DepositBalance, amount)
+}
+
+// WithdrawSubscription journals and applies a deposit balance deduction. It
+// assumes the caller already validated sufficient balance.
+func (s *StateDB) WithdrawSubscription(id common.Hash, amount *big.Int) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return
+	}
+	s.journal.append(subscriptionDepositChange{
+		id:   id,
+		prev: new(big.Int).Set(sub.DepositBalance),
+	})
+	sub.DepositBalance.Sub(sub.DepositBalance, amount)
+}
+
+// SetSubscriptionActive journals and applies an Active flag change.
+func (s *StateDB) SetSubscriptionActive(id common.Hash, active bool) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return
+	}
+	s.journal.append(subscriptionActiveChange{
+		id:   id,
+		prev: sub.Active,
+	})
+	sub.Active = active
+}
+
+// SetSubscriptionExpiry journals and applies a change to a subscription's
+// ExpiresAtBlock, e.g. for Renew.
+func (s *StateDB) SetSubscriptionExpiry(id common.Hash, expiresAtBlock uint64) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return
+	}
+	s.journal.append(subscriptionExpiryChange{
+		id:   id,
+		prev: sub.ExpiresAtBlock,
+	})
+	sub.ExpiresAtBlock = expiresAtBlock
+}
+
+// IncrementSubscriptionCallbackCount journals and bumps the CallbackCount of
+// the subscription that just received a callback.
+func (s *StateDB) IncrementSubscriptionCallbackCount(id common.Hash) {
+	sub, ok := s.subscriptions[id]
+	if !ok {
+		return
+	}
+	s.journal.append(subscriptionCallbackCountChange{
+		id:   id,
+		prev: sub.CallbackCount,
+	})
+	sub.CallbackCount++
+}
+
+// RemoveSubscription deletes a deactivated, empty subscription from state and
+// its event index, used by the block-finalization sweeper so the
+// subscribers-by-event index doesn't grow unbounded. It is a no-op on active
+// subscriptions.
+func (s *StateDB) RemoveSubscription(target common.Address, eventSig common.Hash, id common.Hash) {
+	sub, ok := s.subscriptions[id]
+	if !ok || sub.Active {
+		return
+	}
+	s.journal.append(subscriptionChange{id: id, prev: sub})
+	delete(s.subscriptions, id)
+
+	key := subscriberIndexKey(target, eventSig)
+	ids := s.subscribersByEvent[key]
+	for i, existing := range ids {
+		if existing == id {
+			// Snapshot the slice before splicing: append(ids[:i], ids[i+1:]...)
+			// mutates the same backing array a naive journal snapshot would
+			// alias, so the journaled prev must be an independent copy.
+			prev := make([]common.Hash, len(ids))
+			copy(prev, ids)
+			s.journal.append(subscriptionIndexChange{key: key, prev: prev})
+			s.subscribersByEvent[key] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+// GetSubscribers returns all subscriptions registered against target for
+// eventSig, active or not; callers filter on Active and topic match.
+func (s *StateDB) GetSubscribers(target common.Address, eventSig common.Hash) []*types.Subscription {
+	var out []*types.Subscription
+	for _, id := range s.subscribersByEvent[subscriberIndexKey(target, eventSig)] {
+		if sub, ok := s.subscriptions[id]; ok {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+// IterateSubscriptions walks the subscriptions registered against target for
+// eventSig in index order, starting after from entries were skipped, calling
+// fn for up to count of them (count == 0 means no limit). fn returning false
+// stops the iteration early. This backs the subscription_getSubscriptionsByTarget
+// RPC method and the bind.ContractSubscriber query path.
+func (s *StateDB) IterateSubscriptions(target common.Address, eventSig common.Hash, from, count uint64, fn func(*types.Subscription) bool) {
+	ids := s.subscribersByEvent[subscriberIndexKey(target, eventSig)]
+	if from >= uint64(len(ids)) {
+		return
+	}
+	ids = ids[from:]
+	for i, id := range ids {
+		if count != 0 && uint64(i) >= count {
+			return
+		}
+		sub, ok := s.subscriptions[id]
+		if !ok {
+			continue
+		}
+		if !fn(sub) {
+			return
+		}
+	}
+}
+
+// subscriberIndexKey derives the subscribersByEvent index key for a
+// (target, eventSig) pair.
+func subscriberIndexKey(target common.Address, eventSig common.Hash) common.Hash {
+	return common.BytesToHash(append(target.Bytes(), eventSig.Bytes()...))
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// TestSubscriptionNotifyReorg builds two forks off the same deposited
+// subscription: on the "losing" fork a notification deducts one callback's
+// gas cost from the deposit, on
+// the "winning" fork nothing happens. Reverting to the pre-fork snapshot must
+// restore the deposit balance byte-for-byte, proving NotifySubscribers'
+// mutations are journaled rather than raw writes.
+func TestSubscriptionNotifyReorg(t *testing.T) {
+	sdb, err := New(types.EmptyRootHash, NewDatabaseForTesting())
+	if err != nil {
+		t.Fatalf("failed to create state database: %v", err)
+	}
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0xdeadbeef")
+	subscriber := common.HexToAddress("0x2")
+	subID := types.ComputeSubscriptionID(target, eventSig, subscriber, [3][]common.Hash{})
+
+	gasLimit := uint64(50_000)
+	gasPrice := big.NewInt(1_000_000_000)
+	deposit := new(big.Int).Mul(big.NewInt(int64(gasLimit)), gasPrice)
+	deposit.Mul(deposit, big.NewInt(10)) // enough for ten callbacks
+
+	sdb.SetSubscription(subID, &types.Subscription{
+		ID:                 subID,
+		TargetContract:     target,
+		EventSignature:     eventSig,
+		SubscriberContract: subscriber,
+		CallbackAddress:    subscriber,
+		GasLimit:           gasLimit,
+		GasPrice:           gasPrice,
+		DepositBalance:     new(big.Int).Set(deposit),
+		Active:             true,
+	})
+
+	key := subscriberIndexKey(target, eventSig)
+	sdb.subscribersByEvent[key] = append(sdb.subscribersByEvent[key], subID)
+
+	preForkBalance := new(big.Int).Set(sdb.GetSubscription(subID).DepositBalance)
+	fork := sdb.Snapshot()
+
+	sm := vm.NewSubscriptionManager(sdb)
+	sm.NotifySubscribers(target, eventSig, nil, []byte("event-data"), common.Address{}, 1)
+
+	if got := sdb.GetSubscription(subID).DepositBalance; got.Cmp(preForkBalance) == 0 {
+		t.Fatalf("notification did not deduct gas on the losing fork")
+	}
+
+	sdb.RevertToSnapshot(fork)
+
+	if got := sdb.GetSubscription(subID).DepositBalance; got.Cmp(preForkBalance) != 0 {
+		t.Fatalf("deposit balance not restored after reorg: got %v, want %v", got, preForkBalance)
+	}
+}
+
+// TestSubscriptionRemoveReorg checks that reverting past a RemoveSubscription
+// call restores the subscription to both the subscriptions map and the
+// subscribersByEvent index it was removed from, not just the former.
+func TestSubscriptionRemoveReorg(t *testing.T) {
+	sdb, err := New(types.EmptyRootHash, NewDatabaseForTesting())
+	if err != nil {
+		t.Fatalf("failed to create state database: %v", err)
+	}
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0xdeadbeef")
+	subscriber := common.HexToAddress("0x2")
+	subID := types.ComputeSubscriptionID(target, eventSig, subscriber, [3][]common.Hash{})
+
+	sdb.SetSubscription(subID, &types.Subscription{
+		ID:                 subID,
+		TargetContract:     target,
+		EventSignature:     eventSig,
+		SubscriberContract: subscriber,
+		CallbackAddress:    subscriber,
+		GasLimit:           50_000,
+		GasPrice:           big.NewInt(1),
+		DepositBalance:     big.NewInt(0),
+		Active:             false,
+	})
+
+	key := subscriberIndexKey(target, eventSig)
+	sdb.subscribersByEvent[key] = append(sdb.subscribersByEvent[key], subID)
+
+	fork := sdb.Snapshot()
+
+	sdb.RemoveSubscription(target, eventSig, subID)
+	if sdb.GetSubscription(subID) != nil {
+		t.Fatal("subscription should have been removed")
+	}
+	if got := sdb.GetSubscribers(target, eventSig); len(got) != 0 {
+		t.Fatalf("index should be empty after removal, got %v", got)
+	}
+
+	sdb.RevertToSnapshot(fork)
+
+	if sdb.GetSubscription(subID) == nil {
+		t.Fatal("subscription should have been restored by the revert")
+	}
+	got := sdb.GetSubscribers(target, eventSig)
+	if len(got) != 1 || got[0].ID != subID {
+		t.Fatalf("index should have been restored by the revert, got %v", got)
+	}
+}
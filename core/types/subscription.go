@@ -24,6 +24,26 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
+// TopicFilter is an indexed-topic filter for a single slot of a subscription.
+// A nil or empty TopicFilter matches any value in that slot (wildcard); a
+// non-empty TopicFilter matches if the emitted topic equals any of the
+// contained hashes, mirroring the topic1/topic2/topic3 semantics used by
+// abigen's event bindings and ethereum.FilterQuery.
+type TopicFilter []common.Hash
+
+// Matches reports whether topic satisfies this filter slot.
+func (f TopicFilter) Matches(topic common.Hash) bool {
+	if len(f) == 0 {
+		return true
+	}
+	for _, allowed := range f {
+		if allowed == topic {
+			return true
+		}
+	}
+	return false
+}
+
 // Subscription represents an on-chain event subscription as defined in EIP-8082.
 // When a subscribable event is emitted, the subscription's callback is executed
 // in an isolated context with gas bounded by the subscription parameters.
@@ -47,6 +67,10 @@ type Subscription struct {
 	// CallbackSelector is the 4-byte function selector for the callback function
 	CallbackSelector [4]byte `json:"callbackSelector"`
 
+	// TopicFilters holds up to three optional indexed-topic filters, applied
+	// AND-across-slots and OR-within-a-slot. A nil slot matches any value.
+	TopicFilters [3]TopicFilter `json:"topicFilters"`
+
 	// GasLimit is the maximum gas allowed for callback execution
 	GasLimit uint64 `json:"gasLimit"`
 
@@ -58,19 +82,66 @@ type Subscription struct {
 
 	// Active indicates whether this subscription is active
 	Active bool `json:"active"`
+
+	// ExpiresAtBlock is the first block number at which the subscription is no
+	// longer eligible for notification. Zero means it never expires.
+	ExpiresAtBlock uint64 `json:"expiresAtBlock"`
+
+	// MaxCallbacks caps the number of callbacks this subscription may receive
+	// over its lifetime. Zero means unlimited.
+	MaxCallbacks uint64 `json:"maxCallbacks"`
+
+	// CallbackCount is the number of callbacks delivered so far.
+	CallbackCount uint64 `json:"callbackCount"`
+}
+
+// Expired reports whether the subscription has reached its block-number
+// expiry or its callback budget as of currentBlock.
+func (s *Subscription) Expired(currentBlock uint64) bool {
+	if s.ExpiresAtBlock != 0 && currentBlock >= s.ExpiresAtBlock {
+		return true
+	}
+	if s.MaxCallbacks != 0 && s.CallbackCount >= s.MaxCallbacks {
+		return true
+	}
+	return false
 }
 
-// subscriptionRLP is the RLP encoding structure for subscriptions
+// MatchesTopics reports whether the emitted log topics satisfy every active
+// filter slot of the subscription. topics are the raw (non-anonymous) topics
+// of the emitted log, excluding the event signature itself.
+func (s *Subscription) MatchesTopics(topics []common.Hash) bool {
+	for i, filter := range s.TopicFilters {
+		if len(filter) == 0 {
+			continue
+		}
+		if i >= len(topics) || !filter.Matches(topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// subscriptionRLP is the RLP encoding structure for subscriptions.
+// ExpiresAtBlock, MaxCallbacks and CallbackCount are tagged "optional": the
+// rlp package omits them from the encoding when they (and everything after
+// them) are zero, and fills them with their zero value when decoding a
+// shorter, pre-expiry list. That makes "never expires, unlimited callbacks"
+// the correct default for old entries without any explicit version marker.
 type subscriptionRLP struct {
 	TargetContract     common.Address
 	EventSignature     common.Hash
 	SubscriberContract common.Address
 	CallbackAddress    common.Address
 	CallbackSelector   [4]byte
+	TopicFilters       [3][]common.Hash
 	GasLimit           uint64
 	GasPrice           *big.Int
 	DepositBalance     *big.Int
 	Active             bool
+	ExpiresAtBlock     uint64 `rlp:"optional"`
+	MaxCallbacks       uint64 `rlp:"optional"`
+	CallbackCount      uint64 `rlp:"optional"`
 }
 
 // EncodeRLP implements rlp.Encoder
@@ -81,10 +152,14 @@ func (s *Subscription) EncodeRLP(w rlp.RawWriter) error {
 		SubscriberContract: s.SubscriberContract,
 		CallbackAddress:    s.CallbackAddress,
 		CallbackSelector:   s.CallbackSelector,
+		TopicFilters:       [3][]common.Hash{s.TopicFilters[0], s.TopicFilters[1], s.TopicFilters[2]},
 		GasLimit:           s.GasLimit,
 		GasPrice:           s.GasPrice,
 		DepositBalance:     s.DepositBalance,
 		Active:             s.Active,
+		ExpiresAtBlock:     s.ExpiresAtBlock,
+		MaxCallbacks:       s.MaxCallbacks,
+		CallbackCount:      s.CallbackCount,
 	})
 }
 
@@ -99,23 +174,45 @@ func (s *Subscription) DecodeRLP(stream *rlp.Stream) error {
 	s.SubscriberContract = dec.SubscriberContract
 	s.CallbackAddress = dec.CallbackAddress
 	s.CallbackSelector = dec.CallbackSelector
+	s.TopicFilters = [3]TopicFilter{dec.TopicFilters[0], dec.TopicFilters[1], dec.TopicFilters[2]}
 	s.GasLimit = dec.GasLimit
 	s.GasPrice = dec.GasPrice
 	s.DepositBalance = dec.DepositBalance
 	s.Active = dec.Active
-	s.ID = ComputeSubscriptionID(dec.TargetContract, dec.EventSignature, dec.SubscriberContract)
+	s.ExpiresAtBlock = dec.ExpiresAtBlock
+	s.MaxCallbacks = dec.MaxCallbacks
+	s.CallbackCount = dec.CallbackCount
+	s.ID = ComputeSubscriptionID(dec.TargetContract, dec.EventSignature, dec.SubscriberContract, dec.TopicFilters)
 	return nil
 }
 
-// ComputeSubscriptionID computes the unique subscription ID from its components
-func ComputeSubscriptionID(target common.Address, eventSig common.Hash, subscriber common.Address) common.Hash {
+// ComputeSubscriptionID computes the unique subscription ID from its components.
+// The topic filter is folded into the ID so that two subscriptions on the same
+// (target, event, subscriber) but with different topic filters don't collide.
+func ComputeSubscriptionID(target common.Address, eventSig common.Hash, subscriber common.Address, topicFilters [3][]common.Hash) common.Hash {
 	return crypto.Keccak256Hash(
 		target.Bytes(),
 		eventSig.Bytes(),
 		subscriber.Bytes(),
+		hashTopicFilters(topicFilters),
 	)
 }
 
+// hashTopicFilters returns a canonical hash of a topic filter set so that
+// filters with the same slots hash identically regardless of call site.
+func hashTopicFilters(topicFilters [3][]common.Hash) []byte {
+	h := crypto.NewKeccakState()
+	for _, slot := range topicFilters {
+		h.Write(common.BigToHash(big.NewInt(int64(len(slot)))).Bytes())
+		for _, topic := range slot {
+			h.Write(topic.Bytes())
+		}
+	}
+	var sum common.Hash
+	h.Read(sum[:])
+	return sum.Bytes()
+}
+
 // GasCost calculates the total gas cost for one callback execution
 func (s *Subscription) GasCost() *big.Int {
 	return new(big.Int).Mul(new(big.Int).SetUint64(s.GasLimit), s.GasPrice)
@@ -126,22 +223,6 @@ func (s *Subscription) HasSufficientDeposit() bool {
 	return s.DepositBalance.Cmp(s.GasCost()) >= 0
 }
 
-// DeductGas deducts the gas cost from the deposit balance
-func (s *Subscription) DeductGas() bool {
-	if !s.HasSufficientDeposit() {
-		return false
-	}
-	s.DepositBalance.Sub(s.DepositBalance, s.GasCost())
-	return true
-}
-
-// RefundGas refunds unused gas to the deposit balance
-func (s *Subscription) RefundGas(gasUsed uint64) {
-	unusedGas := s.GasLimit - gasUsed
-	refund := new(big.Int).Mul(new(big.Int).SetUint64(unusedGas), s.GasPrice)
-	s.DepositBalance.Add(s.DepositBalance, refund)
-}
-
 // CallbackExecution represents a pending callback execution
 type CallbackExecution struct {
 	// SubscriptionID identifies the subscription that triggered this callback
@@ -0,0 +1,94 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestTopicFilterMatches(t *testing.T) {
+	a := common.HexToHash("0x1")
+	b := common.HexToHash("0x2")
+
+	tests := []struct {
+		name   string
+		filter TopicFilter
+		topic  common.Hash
+		want   bool
+	}{
+		{"nil filter is wildcard", nil, a, true},
+		{"empty filter is wildcard", TopicFilter{}, a, true},
+		{"matches one of several", TopicFilter{a, b}, b, true},
+		{"no match", TopicFilter{a}, b, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.topic); got != tt.want {
+				t.Errorf("Matches(%v) = %v, want %v", tt.topic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionMatchesTopics(t *testing.T) {
+	a := common.HexToHash("0x1")
+	b := common.HexToHash("0x2")
+
+	sub := &Subscription{
+		TopicFilters: [3]TopicFilter{{a}, nil, {b}},
+	}
+
+	tests := []struct {
+		name   string
+		topics []common.Hash
+		want   bool
+	}{
+		{"all slots satisfied", []common.Hash{a, common.HexToHash("0x99"), b}, true},
+		{"first slot mismatched", []common.Hash{b, common.HexToHash("0x99"), b}, false},
+		{"third slot mismatched", []common.Hash{a, common.HexToHash("0x99"), a}, false},
+		{"too few topics for a filtered slot", []common.Hash{a}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sub.MatchesTopics(tt.topics); got != tt.want {
+				t.Errorf("MatchesTopics(%v) = %v, want %v", tt.topics, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeSubscriptionIDDistinguishesTopicFilters(t *testing.T) {
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0x2")
+	subscriber := common.HexToAddress("0x3")
+
+	noFilter := [3][]common.Hash{}
+	withFilter := [3][]common.Hash{{common.HexToHash("0xa")}, nil, nil}
+
+	id1 := ComputeSubscriptionID(target, eventSig, subscriber, noFilter)
+	id2 := ComputeSubscriptionID(target, eventSig, subscriber, withFilter)
+	if id1 == id2 {
+		t.Fatal("subscriptions with different topic filters must not collide")
+	}
+
+	id1Again := ComputeSubscriptionID(target, eventSig, subscriber, noFilter)
+	if id1 != id1Again {
+		t.Fatal("ComputeSubscriptionID must be deterministic for identical inputs")
+	}
+}
@@ -37,19 +37,30 @@ func NewSubscriptionManager(statedb StateDB) *SubscriptionManager {
 	}
 }
 
-// Subscribe creates a new event subscription
+// SubscribeOpts bundles the parameters needed to create a subscription,
+// including the block-number expiry and callback budget introduced alongside
+// it, so the option set can grow without breaking Subscribe's signature again.
+type SubscribeOpts struct {
+	Target         common.Address
+	EventSig       common.Hash
+	Subscriber     common.Address
+	Callback       common.Address
+	Selector       [4]byte
+	TopicFilters   [3][]common.Hash
+	GasLimit       uint64
+	GasPrice       *big.Int
+	ExpiresAtBlock uint64 // 0 = never expires
+	MaxCallbacks   uint64 // 0 = unlimited
+}
+
+// Subscribe creates a new event subscription from opts. TopicFilters holds up
+// to three optional indexed-topic filters (nil slot = wildcard, OR within a
+// slot, AND across slots), mirroring the topic1/topic2/topic3 model used by
+// abigen's event bindings and ethereum.FilterQuery.
 // Returns the subscription ID and any error
-func (sm *SubscriptionManager) Subscribe(
-	target common.Address,
-	eventSig common.Hash,
-	subscriber common.Address,
-	callback common.Address,
-	selector [4]byte,
-	gasLimit uint64,
-	gasPrice *big.Int,
-) (common.Hash, error) {
+func (sm *SubscriptionManager) Subscribe(opts SubscribeOpts) (common.Hash, error) {
 	// Compute subscription ID
-	subID := types.ComputeSubscriptionID(target, eventSig, subscriber)
+	subID := types.ComputeSubscriptionID(opts.Target, opts.EventSig, opts.Subscriber, opts.TopicFilters)
 
 	// Check if subscription already exists
 	if existing := sm.statedb.GetSubscription(subID); existing != nil && existing.Active {
@@ -60,15 +71,18 @@ func (sm *SubscriptionManager) Subscribe(
 	// Create new subscription
 	sub := &types.Subscription{
 		ID:                 subID,
-		TargetContract:     target,
-		EventSignature:     eventSig,
-		SubscriberContract: subscriber,
-		CallbackAddress:    callback,
-		CallbackSelector:   selector,
-		GasLimit:           gasLimit,
-		GasPrice:           gasPrice,
+		TargetContract:     opts.Target,
+		EventSignature:     opts.EventSig,
+		SubscriberContract: opts.Subscriber,
+		CallbackAddress:    opts.Callback,
+		CallbackSelector:   opts.Selector,
+		TopicFilters:       [3]types.TopicFilter{opts.TopicFilters[0], opts.TopicFilters[1], opts.TopicFilters[2]},
+		GasLimit:           opts.GasLimit,
+		GasPrice:           opts.GasPrice,
 		DepositBalance:     big.NewInt(0),
 		Active:             true,
+		ExpiresAtBlock:     opts.ExpiresAtBlock,
+		MaxCallbacks:       opts.MaxCallbacks,
 	}
 
 	// Store subscription in state
@@ -80,23 +94,51 @@ func (sm *SubscriptionManager) Subscribe(
 		Topics: []common.Hash{
 			common.BytesToHash([]byte("SubscriptionCreated")),
 			subID,
-			common.BytesToHash(target.Bytes()),
-			common.BytesToHash(subscriber.Bytes()),
+			common.BytesToHash(opts.Target.Bytes()),
+			common.BytesToHash(opts.Subscriber.Bytes()),
 		},
-		Data: eventSig.Bytes(),
+		Data: opts.EventSig.Bytes(),
 	})
 
 	return subID, nil
 }
 
-// Unsubscribe removes an event subscription
+// SubscribeSimple is a thin wrapper around Subscribe for callers that don't
+// need an expiry or callback budget, preserving the subscription manager's
+// original positional-argument signature.
+func (sm *SubscriptionManager) SubscribeSimple(
+	target common.Address,
+	eventSig common.Hash,
+	subscriber common.Address,
+	callback common.Address,
+	selector [4]byte,
+	topicFilters [3][]common.Hash,
+	gasLimit uint64,
+	gasPrice *big.Int,
+) (common.Hash, error) {
+	return sm.Subscribe(SubscribeOpts{
+		Target:       target,
+		EventSig:     eventSig,
+		Subscriber:   subscriber,
+		Callback:     callback,
+		Selector:     selector,
+		TopicFilters: topicFilters,
+		GasLimit:     gasLimit,
+		GasPrice:     gasPrice,
+	})
+}
+
+// Unsubscribe removes an event subscription. topicFilters must match the
+// filters the subscription was created with, since they are folded into the
+// subscription ID.
 func (sm *SubscriptionManager) Unsubscribe(
 	target common.Address,
 	eventSig common.Hash,
 	subscriber common.Address,
+	topicFilters [3][]common.Hash,
 ) error {
 	// Compute subscription ID
-	subID := types.ComputeSubscriptionID(target, eventSig, subscriber)
+	subID := types.ComputeSubscriptionID(target, eventSig, subscriber, topicFilters)
 
 	// Get subscription
 	sub := sm.statedb.GetSubscription(subID)
@@ -105,9 +147,8 @@ func (sm *SubscriptionManager) Unsubscribe(
 		return nil
 	}
 
-	// Mark as inactive
-	sub.Active = false
-	sm.statedb.SetSubscription(subID, sub)
+	// Mark as inactive through the journaled path
+	sm.statedb.SetSubscriptionActive(subID, false)
 
 	// Emit subscription removed log
 	sm.statedb.AddLog(&types.Log{
@@ -121,13 +162,19 @@ func (sm *SubscriptionManager) Unsubscribe(
 	return nil
 }
 
-// NotifySubscribers notifies all subscribers of an event emission
+// NotifySubscribers notifies all subscribers of an event emission. topics are
+// the raw indexed topics of the emitted log (excluding the event signature),
+// used to match each active subscription's topic filters before it is
+// charged for the callback. blockNumber is the number of the block containing
+// the emission, checked against each subscription's ExpiresAtBlock.
 // Returns the callback executions to be processed
 func (sm *SubscriptionManager) NotifySubscribers(
 	target common.Address,
 	eventSig common.Hash,
+	topics []common.Hash,
 	eventData []byte,
 	origin common.Address,
+	blockNumber uint64,
 ) []*types.CallbackExecution {
 	// Get all subscribers for this event
 	subscribers := sm.statedb.GetSubscribers(target, eventSig)
@@ -139,6 +186,25 @@ func (sm *SubscriptionManager) NotifySubscribers(
 			continue
 		}
 
+		// Skip-and-deactivate subscriptions that have hit their block-number
+		// expiry or callback budget, rather than notifying them forever.
+		if sub.Expired(blockNumber) {
+			sm.statedb.SetSubscriptionActive(sub.ID, false)
+			sm.statedb.AddLog(&types.Log{
+				Address: params.SubscriptionManagerAddress,
+				Topics: []common.Hash{
+					common.BytesToHash([]byte("SubscriptionExpired")),
+					sub.ID,
+				},
+			})
+			continue
+		}
+
+		// Skip subscribers whose indexed-topic filters don't match this emission
+		if !sub.MatchesTopics(topics) {
+			continue
+		}
+
 		// Check if subscriber has sufficient deposit
 		if !sub.HasSufficientDeposit() {
 			// Insufficient balance, skip and log
@@ -152,13 +218,10 @@ func (sm *SubscriptionManager) NotifySubscribers(
 			continue
 		}
 
-		// Deduct gas from deposit
-		if !sub.DeductGas() {
-			continue
-		}
-
-		// Update subscription in state
-		sm.statedb.SetSubscription(sub.ID, sub)
+		// Deduct gas from deposit via the journaled path so a reorg that drops
+		// this block restores the deposit byte-for-byte.
+		sm.statedb.WithdrawSubscription(sub.ID, sub.GasCost())
+		sm.statedb.IncrementSubscriptionCallbackCount(sub.ID)
 
 		// Build callback data (selector + event data)
 		callbackData := append(sub.CallbackSelector[:], eventData...)
@@ -175,23 +238,30 @@ func (sm *SubscriptionManager) NotifySubscribers(
 		})
 	}
 
+	// This is the natural point at which a (target, eventSig) pair is already
+	// enumerated and subscriptions against it may have just been deactivated
+	// above, so sweep deactivated-and-empty entries here rather than relying
+	// on a separate, unwired finalization hook.
+	sm.SweepExpiredSubscriptions(target, eventSig)
+
 	return callbacks
 }
 
-// Deposit adds funds to a subscription's deposit balance
+// Deposit adds funds to a subscription's deposit balance through the
+// journaled StateDB path, so the deposit unwinds correctly on a reorg.
 func (sm *SubscriptionManager) Deposit(subID common.Hash, amount *big.Int) error {
 	sub := sm.statedb.GetSubscription(subID)
 	if sub == nil {
 		return ErrInvalidSubscription
 	}
 
-	sub.DepositBalance.Add(sub.DepositBalance, amount)
-	sm.statedb.SetSubscription(subID, sub)
+	sm.statedb.DepositSubscription(subID, amount)
 
 	return nil
 }
 
-// Withdraw removes funds from a subscription's deposit balance
+// Withdraw removes funds from a subscription's deposit balance through the
+// journaled StateDB path, so the deposit unwinds correctly on a reorg.
 func (sm *SubscriptionManager) Withdraw(subID common.Hash, amount *big.Int) error {
 	sub := sm.statedb.GetSubscription(subID)
 	if sub == nil {
@@ -202,8 +272,7 @@ func (sm *SubscriptionManager) Withdraw(subID common.Hash, amount *big.Int) erro
 		return ErrInsufficientDeposit
 	}
 
-	sub.DepositBalance.Sub(sub.DepositBalance, amount)
-	sm.statedb.SetSubscription(subID, sub)
+	sm.statedb.WithdrawSubscription(subID, amount)
 
 	return nil
 }
@@ -222,15 +291,49 @@ func (sm *SubscriptionManager) GetSubscription(subID common.Hash) *types.Subscri
 	return sm.statedb.GetSubscription(subID)
 }
 
-// RefundGas refunds unused gas to a subscription's deposit
+// RefundGas refunds unused gas to a subscription's deposit through the
+// journaled StateDB path.
 func (sm *SubscriptionManager) RefundGas(subID common.Hash, gasUsed uint64) {
 	sub := sm.statedb.GetSubscription(subID)
 	if sub == nil {
 		return
 	}
 
-	sub.RefundGas(gasUsed)
-	sm.statedb.SetSubscription(subID, sub)
+	unusedGas := sub.GasLimit - gasUsed
+	refund := new(big.Int).Mul(new(big.Int).SetUint64(unusedGas), sub.GasPrice)
+	sm.statedb.DepositSubscription(subID, refund)
+}
+
+// Renew extends a subscription's block-number expiry. Only the subscriber
+// that created the subscription may renew it.
+func (sm *SubscriptionManager) Renew(subID common.Hash, caller common.Address, newExpiry uint64) error {
+	sub := sm.statedb.GetSubscription(subID)
+	if sub == nil || !sub.Active {
+		return ErrInvalidSubscription
+	}
+	if sub.SubscriberContract != caller {
+		return ErrUnauthorizedSubscriber
+	}
+
+	sm.statedb.SetSubscriptionExpiry(subID, newExpiry)
+
+	return nil
+}
+
+// SweepExpiredSubscriptions removes deactivated-and-empty subscriptions
+// (Active == false, DepositBalance == 0) registered against target/eventSig
+// from state, so the subscribers-by-event index doesn't grow unbounded.
+// NotifySubscribers calls it for every (target, eventSig) pair it processes,
+// since that's the point at which a subscription may have just become
+// deactivated and empty; it may also be called directly, e.g. by a client
+// pruning a pair that no longer receives events.
+func (sm *SubscriptionManager) SweepExpiredSubscriptions(target common.Address, eventSig common.Hash) {
+	for _, sub := range sm.statedb.GetSubscribers(target, eventSig) {
+		if sub.Active || sub.DepositBalance.Sign() != 0 {
+			continue
+		}
+		sm.statedb.RemoveSubscription(target, eventSig, sub.ID)
+	}
 }
 
 // UpdateSubscription updates subscription parameters
@@ -0,0 +1,223 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeSubscriptionStateDB is a minimal in-memory StateDB stand-in covering
+// only the subscription-related methods SubscriptionManager needs, without
+// journaling - these tests exercise SubscriptionManager's own logic
+// (expiry, renewal, sweeping), not reorg safety, which is covered in
+// core/state.
+type fakeSubscriptionStateDB struct {
+	subs  map[common.Hash]*types.Subscription
+	index map[common.Hash][]common.Hash
+	logs  []*types.Log
+}
+
+func newFakeSubscriptionStateDB() *fakeSubscriptionStateDB {
+	return &fakeSubscriptionStateDB{
+		subs:  make(map[common.Hash]*types.Subscription),
+		index: make(map[common.Hash][]common.Hash),
+	}
+}
+
+func (f *fakeSubscriptionStateDB) indexKey(target common.Address, eventSig common.Hash) common.Hash {
+	return common.BytesToHash(append(target.Bytes(), eventSig.Bytes()...))
+}
+
+func (f *fakeSubscriptionStateDB) GetSubscription(id common.Hash) *types.Subscription {
+	return f.subs[id]
+}
+
+func (f *fakeSubscriptionStateDB) SetSubscription(id common.Hash, sub *types.Subscription) {
+	if _, ok := f.subs[id]; !ok {
+		key := f.indexKey(sub.TargetContract, sub.EventSignature)
+		f.index[key] = append(f.index[key], id)
+	}
+	f.subs[id] = sub
+}
+
+func (f *fakeSubscriptionStateDB) DepositSubscription(id common.Hash, amount *big.Int) {
+	if sub, ok := f.subs[id]; ok {
+		sub.DepositBalance.Add(sub.DepositBalance, amount)
+	}
+}
+
+func (f *fakeSubscriptionStateDB) WithdrawSubscription(id common.Hash, amount *big.Int) {
+	if sub, ok := f.subs[id]; ok {
+		sub.DepositBalance.Sub(sub.DepositBalance, amount)
+	}
+}
+
+func (f *fakeSubscriptionStateDB) SetSubscriptionActive(id common.Hash, active bool) {
+	if sub, ok := f.subs[id]; ok {
+		sub.Active = active
+	}
+}
+
+func (f *fakeSubscriptionStateDB) SetSubscriptionExpiry(id common.Hash, expiresAtBlock uint64) {
+	if sub, ok := f.subs[id]; ok {
+		sub.ExpiresAtBlock = expiresAtBlock
+	}
+}
+
+func (f *fakeSubscriptionStateDB) IncrementSubscriptionCallbackCount(id common.Hash) {
+	if sub, ok := f.subs[id]; ok {
+		sub.CallbackCount++
+	}
+}
+
+func (f *fakeSubscriptionStateDB) RemoveSubscription(target common.Address, eventSig common.Hash, id common.Hash) {
+	sub, ok := f.subs[id]
+	if !ok || sub.Active {
+		return
+	}
+	delete(f.subs, id)
+	key := f.indexKey(target, eventSig)
+	ids := f.index[key]
+	for i, existing := range ids {
+		if existing == id {
+			f.index[key] = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *fakeSubscriptionStateDB) GetSubscribers(target common.Address, eventSig common.Hash) []*types.Subscription {
+	var out []*types.Subscription
+	for _, id := range f.index[f.indexKey(target, eventSig)] {
+		if sub, ok := f.subs[id]; ok {
+			out = append(out, sub)
+		}
+	}
+	return out
+}
+
+func (f *fakeSubscriptionStateDB) AddLog(log *types.Log) {
+	f.logs = append(f.logs, log)
+}
+
+func newTestSubscription(target common.Address, eventSig common.Hash, subscriber common.Address) *types.Subscription {
+	id := types.ComputeSubscriptionID(target, eventSig, subscriber, [3][]common.Hash{})
+	return &types.Subscription{
+		ID:                 id,
+		TargetContract:     target,
+		EventSignature:     eventSig,
+		SubscriberContract: subscriber,
+		CallbackAddress:    subscriber,
+		GasLimit:           21000,
+		GasPrice:           big.NewInt(1),
+		DepositBalance:     big.NewInt(1_000_000),
+		Active:             true,
+	}
+}
+
+func TestNotifySubscribersExpiresByBlock(t *testing.T) {
+	statedb := newFakeSubscriptionStateDB()
+	sm := NewSubscriptionManager(statedb)
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0x2")
+	subscriber := common.HexToAddress("0x3")
+	sub := newTestSubscription(target, eventSig, subscriber)
+	sub.ExpiresAtBlock = 100
+	statedb.SetSubscription(sub.ID, sub)
+
+	callbacks := sm.NotifySubscribers(target, eventSig, nil, []byte("data"), common.Address{}, 100)
+	if len(callbacks) != 0 {
+		t.Fatalf("expected no callbacks for an expired subscription, got %d", len(callbacks))
+	}
+	if statedb.GetSubscription(sub.ID).Active {
+		t.Fatal("expired subscription should have been deactivated")
+	}
+}
+
+func TestNotifySubscribersExpiresByMaxCallbacks(t *testing.T) {
+	statedb := newFakeSubscriptionStateDB()
+	sm := NewSubscriptionManager(statedb)
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0x2")
+	subscriber := common.HexToAddress("0x3")
+	sub := newTestSubscription(target, eventSig, subscriber)
+	sub.MaxCallbacks = 1
+	sub.CallbackCount = 1
+	statedb.SetSubscription(sub.ID, sub)
+
+	callbacks := sm.NotifySubscribers(target, eventSig, nil, []byte("data"), common.Address{}, 1)
+	if len(callbacks) != 0 {
+		t.Fatalf("expected no callbacks once MaxCallbacks is reached, got %d", len(callbacks))
+	}
+	if statedb.GetSubscription(sub.ID).Active {
+		t.Fatal("exhausted subscription should have been deactivated")
+	}
+}
+
+func TestRenewRejectsWrongCaller(t *testing.T) {
+	statedb := newFakeSubscriptionStateDB()
+	sm := NewSubscriptionManager(statedb)
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0x2")
+	subscriber := common.HexToAddress("0x3")
+	sub := newTestSubscription(target, eventSig, subscriber)
+	statedb.SetSubscription(sub.ID, sub)
+
+	if err := sm.Renew(sub.ID, common.HexToAddress("0x4"), 500); err != ErrUnauthorizedSubscriber {
+		t.Fatalf("expected ErrUnauthorizedSubscriber, got %v", err)
+	}
+
+	if err := sm.Renew(sub.ID, subscriber, 500); err != nil {
+		t.Fatalf("unexpected error renewing: %v", err)
+	}
+	if got := statedb.GetSubscription(sub.ID).ExpiresAtBlock; got != 500 {
+		t.Fatalf("ExpiresAtBlock = %d, want 500", got)
+	}
+}
+
+func TestSweepExpiredSubscriptions(t *testing.T) {
+	statedb := newFakeSubscriptionStateDB()
+	sm := NewSubscriptionManager(statedb)
+
+	target := common.HexToAddress("0x1")
+	eventSig := common.HexToHash("0x2")
+
+	empty := newTestSubscription(target, eventSig, common.HexToAddress("0x3"))
+	empty.Active = false
+	empty.DepositBalance = big.NewInt(0)
+	statedb.SetSubscription(empty.ID, empty)
+
+	funded := newTestSubscription(target, eventSig, common.HexToAddress("0x4"))
+	funded.Active = false
+	statedb.SetSubscription(funded.ID, funded)
+
+	sm.SweepExpiredSubscriptions(target, eventSig)
+
+	if statedb.GetSubscription(empty.ID) != nil {
+		t.Error("deactivated, empty subscription should have been swept")
+	}
+	if statedb.GetSubscription(funded.ID) == nil {
+		t.Error("deactivated subscription with remaining deposit should not be swept")
+	}
+}
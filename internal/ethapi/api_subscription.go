@@ -0,0 +1,175 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// SubscriptionAPI exposes EIP-8082 on-chain subscription state under the
+// "subscription" RPC namespace, the equivalent of eth_getLogs/eth_subscribe
+// for subscriptions.
+//
+// GetSubscription/GetSubscriptionsByTarget/GetBalance only read StateDB and
+// work against any Backend today. The "callbacks"/"lifecycle" streams are
+// narrower in scope: they assume Backend additionally exposes CurrentState,
+// SubscribeCallbackExecutionEvent and SubscribeSubscriptionLogEvent, backed
+// by a block-import-driven event feed analogous to eth/filters' log feed.
+// Wiring that feed through eth/filters and a concrete Backend is tracked as
+// follow-up work and is not part of this change; until it lands, Subscribe's
+// "callbacks" and "lifecycle" streams have no running backend to attach to.
+type SubscriptionAPI struct {
+	b Backend
+}
+
+// NewSubscriptionAPI creates a new subscription_ namespace API.
+func NewSubscriptionAPI(b Backend) *SubscriptionAPI {
+	return &SubscriptionAPI{b: b}
+}
+
+// GetSubscription returns the current state of a single subscription.
+func (api *SubscriptionAPI) GetSubscription(ctx context.Context, id common.Hash) (*types.Subscription, error) {
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return state.GetSubscription(id), nil
+}
+
+// GetSubscriptionsByTarget pages through the subscriptions registered against
+// target for eventSig, starting after from entries and returning at most
+// count of them.
+func (api *SubscriptionAPI) GetSubscriptionsByTarget(ctx context.Context, target common.Address, eventSig common.Hash, from, count uint64) ([]*types.Subscription, error) {
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	var subs []*types.Subscription
+	state.IterateSubscriptions(target, eventSig, from, count, func(sub *types.Subscription) bool {
+		subs = append(subs, sub)
+		return true
+	})
+	return subs, nil
+}
+
+// GetBalance returns the deposit balance of a subscription.
+func (api *SubscriptionAPI) GetBalance(ctx context.Context, id common.Hash) (*big.Int, error) {
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+	if err != nil {
+		return nil, err
+	}
+	sub := state.GetSubscription(id)
+	if sub == nil {
+		return big.NewInt(0), nil
+	}
+	return sub.DepositBalance, nil
+}
+
+// SubscriptionFilter narrows a "callbacks" or "lifecycle" stream to a single
+// target contract and/or event signature; a zero value matches everything.
+type SubscriptionFilter struct {
+	Target   *common.Address `json:"target"`
+	EventSig *common.Hash    `json:"eventSig"`
+}
+
+func (f *SubscriptionFilter) matches(target common.Address, eventSig common.Hash) bool {
+	if f.Target != nil && *f.Target != target {
+		return false
+	}
+	if f.EventSig != nil && *f.EventSig != eventSig {
+		return false
+	}
+	return true
+}
+
+// Subscribe lets a client stream EIP-8082 subscription activity as blocks are
+// imported. The first argument selects the stream: "callbacks" pushes
+// types.CallbackExecution records for every callback that actually ran,
+// "lifecycle" pushes created/removed/insufficient-deposit/expired log events.
+func (api *SubscriptionAPI) Subscribe(ctx context.Context, kind string, filter SubscriptionFilter) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	switch kind {
+	case "callbacks":
+		return api.subscribeCallbacks(ctx, notifier, filter)
+	case "lifecycle":
+		return api.subscribeLifecycle(ctx, notifier, filter)
+	default:
+		return nil, errors.New("unknown subscription stream: must be \"callbacks\" or \"lifecycle\"")
+	}
+}
+
+func (api *SubscriptionAPI) subscribeCallbacks(ctx context.Context, notifier *rpc.Notifier, filter SubscriptionFilter) (*rpc.Subscription, error) {
+	rpcSub := notifier.CreateSubscription()
+	callbacks := make(chan *types.CallbackExecution, 128)
+	chainSub := api.b.SubscribeCallbackExecutionEvent(callbacks)
+
+	go func() {
+		defer chainSub.Unsubscribe()
+		for {
+			select {
+			case cb := <-callbacks:
+				sub := api.b.CurrentState().GetSubscription(cb.SubscriptionID)
+				if sub != nil && !filter.matches(sub.TargetContract, sub.EventSignature) {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, cb)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (api *SubscriptionAPI) subscribeLifecycle(ctx context.Context, notifier *rpc.Notifier, filter SubscriptionFilter) (*rpc.Subscription, error) {
+	rpcSub := notifier.CreateSubscription()
+	logs := make(chan *types.SubscriptionLog, 128)
+	chainSub := api.b.SubscribeSubscriptionLogEvent(logs)
+
+	go func() {
+		defer chainSub.Unsubscribe()
+		for {
+			select {
+			case l := <-logs:
+				sub := api.b.CurrentState().GetSubscription(l.SubscriptionID)
+				if sub != nil && !filter.matches(sub.TargetContract, sub.EventSignature) {
+					continue
+				}
+				notifier.Notify(rpcSub.ID, l)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
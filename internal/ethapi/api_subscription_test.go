@@ -0,0 +1,51 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSubscriptionFilterMatches(t *testing.T) {
+	target := common.HexToAddress("0x1")
+	otherTarget := common.HexToAddress("0x2")
+	eventSig := common.HexToHash("0xa")
+	otherEventSig := common.HexToHash("0xb")
+
+	tests := []struct {
+		name   string
+		filter SubscriptionFilter
+		want   bool
+	}{
+		{"zero value matches everything", SubscriptionFilter{}, true},
+		{"matching target only", SubscriptionFilter{Target: &target}, true},
+		{"mismatched target", SubscriptionFilter{Target: &otherTarget}, false},
+		{"matching event sig only", SubscriptionFilter{EventSig: &eventSig}, true},
+		{"mismatched event sig", SubscriptionFilter{EventSig: &otherEventSig}, false},
+		{"matching target and event sig", SubscriptionFilter{Target: &target, EventSig: &eventSig}, true},
+		{"matching target but mismatched event sig", SubscriptionFilter{Target: &target, EventSig: &otherEventSig}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.matches(target, eventSig); got != tt.want {
+				t.Errorf("matches(%v, %v) = %v, want %v", target, eventSig, got, tt.want)
+			}
+		})
+	}
+}